@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AllFields returns every non-note field across every Form/Group in the
+// survey, in declaration order.
+func (s *Survey) AllFields() []*Field {
+	fields := make([]*Field, 0)
+	for _, f := range s.Forms {
+		fields = append(fields, f.ValueFields()...)
+	}
+	return fields
+}
+
+// PreloadAnswers populates s.answers from, in increasing priority order: an
+// external answer file, a DHUH_<KEY_UPPER> environment variable per field,
+// --set key=value pairs, and --set-file key=@path pairs whose value is read
+// from a file. It must run before Run() so that, reusing the same restore
+// pathway Output-based answers already use, any field that's already
+// answered is skipped entirely instead of prompted.
+func (s *Survey) PreloadAnswers(answerFile string, sets, setFiles []string) error {
+	fieldsByKey := map[string]*Field{}
+	for _, field := range s.AllFields() {
+		fieldsByKey[field.Key] = field
+	}
+
+	if answerFile != "" {
+		a, err := readAnswers(answerFile)
+		if err != nil {
+			return err
+		}
+		for k, v := range a {
+			s.answers[k] = v
+		}
+	}
+
+	for _, field := range s.AllFields() {
+		envKey := "DHUH_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(field.Key))
+		if v, ok := os.LookupEnv(envKey); ok {
+			value, err := coerceAnswer(field, v)
+			if err != nil {
+				return err
+			}
+			s.answers[field.Key] = value
+		}
+	}
+
+	for _, set := range sets {
+		key, value, err := splitKeyValue(set, "--set")
+		if err != nil {
+			return err
+		}
+		coerced, err := coerceAnswer(fieldsByKey[key], value)
+		if err != nil {
+			return err
+		}
+		s.answers[key] = coerced
+	}
+
+	for _, setFile := range setFiles {
+		key, ref, err := splitKeyValue(setFile, "--set-file")
+		if err != nil {
+			return err
+		}
+		path, ok := strings.CutPrefix(ref, "@")
+		if !ok {
+			return fmt.Errorf("--set-file value for %q must reference a file as @path", key)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		value, err := coerceAnswer(fieldsByKey[key], strings.TrimRight(string(b), "\n"))
+		if err != nil {
+			return err
+		}
+		s.answers[key] = value
+	}
+
+	return nil
+}
+
+// coerceAnswer converts a raw string answer (from an env var, --set or
+// --set-file) into the shape field's huh.Field constructor expects out of
+// s.answers: a []interface{} of options for multiselect, a bool for confirm,
+// and the string as-is for everything else (including an unknown field,
+// which field is nil for).
+func coerceAnswer(field *Field, raw string) (interface{}, error) {
+	if field == nil {
+		return raw, nil
+	}
+
+	switch field.Type {
+	case "multiselect":
+		values := make([]interface{}, 0)
+		for _, v := range strings.Split(raw, ",") {
+			values = append(values, v)
+		}
+		return values, nil
+	case "confirm":
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("value for %q must be a boolean: %w", field.Key, err)
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
+}
+
+func splitKeyValue(raw, flagName string) (string, string, error) {
+	key, value, ok := strings.Cut(raw, "=")
+	if !ok {
+		return "", "", fmt.Errorf("%s expects key=value, got %q", flagName, raw)
+	}
+	return key, value, nil
+}
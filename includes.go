@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// resolveIncludes composes s with the survey files listed in s.Includes,
+// similar to how Hugo composes a site from a list of theme components: each
+// include is loaded and recursively resolved against its own Includes, the
+// results are merged left to right, and s itself is merged on top so its own
+// Theme/Output/Confirm/Summary always take precedence. baseDir resolves
+// relative include paths, ref identifies s for cycle detection, and seen is
+// shared across the whole recursion.
+func resolveIncludes(s *Survey, baseDir, ref string, seen map[string]bool) (*Survey, error) {
+	if seen[ref] {
+		return nil, fmt.Errorf("include cycle detected at %s", ref)
+	}
+	seen[ref] = true
+	defer delete(seen, ref)
+
+	var merged *Survey
+	for _, include := range s.Includes {
+		includeRef := resolveIncludeRef(baseDir, include)
+
+		doc, err := loadIncludedSurvey(includeRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load include %s: %w", include, err)
+		}
+
+		includeBaseDir := baseDir
+		if !isURL(includeRef) {
+			includeBaseDir = filepath.Dir(includeRef)
+		}
+
+		resolved, err := resolveIncludes(doc, includeBaseDir, includeRef, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		if merged == nil {
+			merged = resolved
+		} else {
+			merged = mergeSurveys(merged, resolved, false)
+		}
+	}
+
+	if merged == nil {
+		return s, nil
+	}
+
+	return mergeSurveys(merged, s, true), nil
+}
+
+// loadIncludedSurvey reads and unmarshals a single survey file without
+// recursing into its Includes; resolveIncludes does that separately so it
+// can track the shared cycle-detection state.
+func loadIncludedSurvey(ref string) (*Survey, error) {
+	b, err := readIncludeSource(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Survey{}
+	switch fileType(ref) {
+	case "yaml":
+		if err := yaml.Unmarshal(b, doc); err != nil {
+			return nil, err
+		}
+	case "json":
+		if err := json.Unmarshal(b, doc); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedFileExtension
+	}
+
+	return doc, nil
+}
+
+func readIncludeSource(ref string) ([]byte, error) {
+	if isURL(ref) {
+		resp, err := http.Get(ref)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch %s: %s", ref, resp.Status)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return os.ReadFile(ref)
+}
+
+func resolveIncludeRef(baseDir, ref string) string {
+	if isURL(ref) || filepath.IsAbs(ref) {
+		return ref
+	}
+	return filepath.Join(baseDir, ref)
+}
+
+func isURL(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+// mergeSurveys combines left and right into a new Survey: Forms/Groups/Fields
+// are matched by Key (left's ordering is kept, right's redefinitions replace
+// in place, everything else is appended). For scalar fields left wins when
+// set, except when topLevel is true, in which case right (the file doing the
+// including) always wins for Theme/ThemeStyles/Output/Format/Template/Confirm/Summary.
+func mergeSurveys(left, right *Survey, topLevel bool) *Survey {
+	merged := &Survey{
+		Name:        firstNonEmpty(left.Name, right.Name),
+		Version:     firstNonEmpty(left.Version, right.Version),
+		Description: firstNonEmpty(left.Description, right.Description),
+		Accessible:  left.Accessible || right.Accessible,
+		Theme:       firstNonEmpty(left.Theme, right.Theme),
+		ThemeStyles: firstNonEmptyThemeStyles(left.ThemeStyles, right.ThemeStyles),
+		Output:      firstNonEmpty(left.Output, right.Output),
+		Format:      firstNonEmpty(left.Format, right.Format),
+		Template:    firstNonEmpty(left.Template, right.Template),
+		Confirm:     left.Confirm,
+		Summary:     left.Summary || right.Summary,
+		Forms:       mergeForms(left.Forms, right.Forms),
+	}
+
+	if topLevel {
+		merged.Theme = right.Theme
+		merged.ThemeStyles = right.ThemeStyles
+		merged.Output = right.Output
+		merged.Format = right.Format
+		merged.Template = right.Template
+		merged.Confirm = right.Confirm
+		merged.Summary = right.Summary
+	}
+
+	return merged
+}
+
+func mergeForms(left, right []*Form) []*Form {
+	groups := mergeGroups(flattenGroups(left), flattenGroups(right))
+	if len(groups) == 0 {
+		return nil
+	}
+	return []*Form{{Groups: groups}}
+}
+
+func flattenGroups(forms []*Form) []*Group {
+	groups := make([]*Group, 0)
+	for _, f := range forms {
+		groups = append(groups, f.Groups...)
+	}
+	return groups
+}
+
+func mergeGroups(left, right []*Group) []*Group {
+	index := map[string]int{}
+	merged := make([]*Group, 0, len(left)+len(right))
+
+	for _, g := range left {
+		merged = append(merged, g)
+		if g.Key != "" {
+			index[g.Key] = len(merged) - 1
+		}
+	}
+
+	for _, g := range right {
+		if g.Key != "" {
+			if i, ok := index[g.Key]; ok {
+				merged[i] = mergeGroup(merged[i], g)
+				continue
+			}
+		}
+		merged = append(merged, g)
+		if g.Key != "" {
+			index[g.Key] = len(merged) - 1
+		}
+	}
+
+	return merged
+}
+
+func mergeGroup(left, right *Group) *Group {
+	return &Group{
+		Key:         left.Key,
+		Title:       firstNonEmpty(left.Title, right.Title),
+		Description: firstNonEmpty(left.Description, right.Description),
+		Fields:      mergeFields(left.Fields, right.Fields),
+	}
+}
+
+// mergeFields concatenates left's and right's fields in declaration order,
+// except a right field with the same Key as a left field redefines it in
+// place instead of appending.
+func mergeFields(left, right []*Field) []*Field {
+	index := map[string]int{}
+	merged := make([]*Field, 0, len(left)+len(right))
+
+	for _, f := range left {
+		merged = append(merged, f)
+		if f.Key != "" {
+			index[f.Key] = len(merged) - 1
+		}
+	}
+
+	for _, f := range right {
+		if f.Key != "" {
+			if i, ok := index[f.Key]; ok {
+				merged[i] = f
+				continue
+			}
+		}
+		merged = append(merged, f)
+		if f.Key != "" {
+			index[f.Key] = len(merged) - 1
+		}
+	}
+
+	return merged
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+func firstNonEmptyThemeStyles(a, b map[string]StyleSpec) map[string]StyleSpec {
+	if len(a) > 0 {
+		return a
+	}
+	return b
+}
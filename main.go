@@ -3,11 +3,16 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"html/template"
 	"log"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
@@ -24,18 +29,25 @@ var (
 )
 
 type Survey struct {
-	path    string
-	answers map[string]interface{}
+	path           string
+	answers        map[string]interface{}
+	schema         *JSONSchema
+	nonInteractive bool
 
-	Name        string  `yaml:"name" json:"name"`
-	Version     string  `yaml:"version" json:"version"`
-	Description string  `yaml:"description" json:"description"`
-	Theme       string  `yaml:"theme" json:"theme"`
-	Accessible  bool    `yaml:"accessible" json:"accessible"`
-	Output      string  `yaml:"output" json:"output"`
-	Forms       []*Form `yaml:"forms" json:"forms"`
-	Summary     bool    `yaml:"summary" json:"summary"`
-	Confirm     Confirm `yaml:"confirm" json:"confirm"`
+	Name        string   `yaml:"name" json:"name"`
+	Version     string   `yaml:"version" json:"version"`
+	Description string   `yaml:"description" json:"description"`
+	Theme       string   `yaml:"theme" json:"theme"`
+	Accessible  bool     `yaml:"accessible" json:"accessible"`
+	Output      string   `yaml:"output" json:"output"`
+	Forms       []*Form  `yaml:"forms" json:"forms"`
+	Summary     bool     `yaml:"summary" json:"summary"`
+	Confirm     Confirm  `yaml:"confirm" json:"confirm"`
+	Includes    []string `yaml:"includes,omitempty" json:"includes,omitempty"`
+	Format      string   `yaml:"format,omitempty" json:"format,omitempty"`
+	Template    string   `yaml:"template,omitempty" json:"template,omitempty"`
+
+	ThemeStyles map[string]StyleSpec `yaml:"themeStyles,omitempty" json:"themeStyles,omitempty"`
 }
 
 type Form struct {
@@ -51,9 +63,13 @@ func (f *Form) ValueFields() []*Field {
 }
 
 type Group struct {
-	Title       string   `yaml:"title" json:"title"`
-	Description string   `yaml:"description" json:"description"`
-	Fields      []*Field `yaml:"fields" json:"fields"`
+	Key          string   `yaml:"key,omitempty" json:"key,omitempty"`
+	Title        string   `yaml:"title" json:"title"`
+	Description  string   `yaml:"description" json:"description"`
+	Fields       []*Field `yaml:"fields" json:"fields"`
+	VisibleWhen  string   `yaml:"visibleWhen,omitempty" json:"visibleWhen,omitempty"`
+	RequiredWhen string   `yaml:"requiredWhen,omitempty" json:"requiredWhen,omitempty"`
+	SkipWhen     string   `yaml:"skipWhen,omitempty" json:"skipWhen,omitempty"`
 }
 
 func (g *Group) ValueFields() []*Field {
@@ -70,15 +86,65 @@ func (g *Group) ValueFields() []*Field {
 }
 
 type Field struct {
-	ref         huh.Field
-	Key         string         `yaml:"key" json:"key"`
-	Type        string         `yaml:"type" json:"type"`
-	Title       string         `yaml:"title" json:"title"`
-	Description string         `yaml:"description" json:"description"`
-	Required    bool           `yaml:"required" json:"required"`
-	Placeholder string         `yaml:"placeholder,omitempty" json:"placeholder,omitempty"`
-	Default     interface{}    `yaml:"default,omitempty" json:"default,omitempty"`
-	Options     []SelectOption `yaml:"options,omitempty" json:"options,omitempty"`
+	ref          huh.Field
+	Key          string         `yaml:"key" json:"key"`
+	Type         string         `yaml:"type" json:"type"`
+	Title        string         `yaml:"title" json:"title"`
+	Description  string         `yaml:"description" json:"description"`
+	Required     bool           `yaml:"required" json:"required"`
+	Placeholder  string         `yaml:"placeholder,omitempty" json:"placeholder,omitempty"`
+	Default      interface{}    `yaml:"default,omitempty" json:"default,omitempty"`
+	Options      []SelectOption `yaml:"options,omitempty" json:"options,omitempty"`
+	Pattern      string         `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	MinLength    *int           `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength    *int           `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	Minimum      *float64       `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum      *float64       `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	OptionsFrom  string         `yaml:"optionsFrom,omitempty" json:"optionsFrom,omitempty"`
+	VisibleWhen  string         `yaml:"visibleWhen,omitempty" json:"visibleWhen,omitempty"`
+	RequiredWhen string         `yaml:"requiredWhen,omitempty" json:"requiredWhen,omitempty"`
+	SkipWhen     string         `yaml:"skipWhen,omitempty" json:"skipWhen,omitempty"`
+}
+
+// validate applies the Required, Pattern, MinLength/MaxLength and
+// Minimum/Maximum checks to a raw string value. It backs both the bespoke
+// survey format (where these are set directly on the field) and fields
+// synthesized from a JSON Schema document.
+func (f *Field) validate(v string) error {
+	if f.Required && v == "" {
+		return fmt.Errorf("value is required")
+	}
+	if v == "" {
+		return nil
+	}
+	if f.MinLength != nil && len(v) < *f.MinLength {
+		return fmt.Errorf("value must be at least %d characters", *f.MinLength)
+	}
+	if f.MaxLength != nil && len(v) > *f.MaxLength {
+		return fmt.Errorf("value must be at most %d characters", *f.MaxLength)
+	}
+	if f.Pattern != "" {
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", f.Pattern, err)
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("value does not match pattern %q", f.Pattern)
+		}
+	}
+	if f.Minimum != nil || f.Maximum != nil {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("value must be numeric")
+		}
+		if f.Minimum != nil && n < *f.Minimum {
+			return fmt.Errorf("value must be >= %v", *f.Minimum)
+		}
+		if f.Maximum != nil && n > *f.Maximum {
+			return fmt.Errorf("value must be <= %v", *f.Maximum)
+		}
+	}
+	return nil
 }
 
 type SelectOption struct {
@@ -92,65 +158,227 @@ type Confirm struct {
 	Description string `yaml:"description" json:"description"`
 }
 
+// MissingAnswerError is returned by Survey.Run when running non-interactively
+// and a required field has no supplied value, instead of prompting for it.
+type MissingAnswerError struct {
+	Key string `json:"key"`
+}
+
+func (e *MissingAnswerError) Error() string {
+	return fmt.Sprintf("missing required value for %q in non-interactive mode", e.Key)
+}
+
+// hasDefault reports whether f.Default already resolves to a usable value,
+// the same way accepting the default interactively would satisfy a required
+// field without typing anything: in non-interactive mode this means the
+// field isn't actually missing a value.
+func hasDefault(f *Field) bool {
+	switch v := f.Default.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
 func (s *Survey) Run() error {
-	theme := getTheme(s.Theme)
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewNote().
-				Title(strings.TrimSpace(fmt.Sprintf("%s, version %s", s.Name, s.Version))).
-				Description(strings.TrimSpace(fmt.Sprintf("Reading questions from %s, writing answers to %s\n\n%s", s.path, s.Output, s.Description))),
-		),
-	).WithTheme(&theme).WithAccessible(s.Accessible)
-
-	if err := form.Run(); err != nil {
+	theme, err := getTheme(s)
+	if err != nil {
 		return err
 	}
 
-	for _, f := range s.Forms {
-		var groups []*huh.Group
+	// In non-interactive mode nothing is ever prompted for, including this
+	// intro note: fields are either pre-answered, defaulted, or the run
+	// already failed with a MissingAnswerError.
+	if !s.nonInteractive {
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewNote().
+					Title(strings.TrimSpace(fmt.Sprintf("%s, version %s", s.Name, s.Version))).
+					Description(strings.TrimSpace(fmt.Sprintf("Reading questions from %s, writing answers to %s\n\n%s", s.path, s.Output, s.Description))),
+			),
+		).WithTheme(&theme).WithAccessible(s.Accessible)
 
+		if err := form.Run(); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range s.Forms {
 		for _, g := range f.Groups {
-			fields := make([]huh.Field, 0)
+			// Groups are rebuilt one at a time, right before they run, so
+			// VisibleWhen/RequiredWhen/OptionsFrom templates see the answers
+			// from every group that ran before them.
+			visible, err := s.evalCondition(g.VisibleWhen, g.SkipWhen, true)
+			if err != nil {
+				return err
+			}
+			if !visible {
+				continue
+			}
 
-			for _, field := range g.Fields {
-				switch field.Type {
-				case "note":
-					fields = append(fields, s.NewNoteField(field))
-				case "input":
-					fields = append(fields, s.NewInputField(field))
-				case "text":
-					fields = append(fields, s.NewTextField(field))
-				case "select":
-					fields = append(fields, s.NewSelectField(field))
-				case "multiselect":
-					fields = append(fields, s.NewMultiSelectField(field))
-				case "confirm":
-					fields = append(fields, s.NewConfirmField(field))
-				default:
-					return fmt.Errorf("unsupported field type: %s", field.Type)
+			fields, err := s.buildGroupFields(g)
+			if err != nil {
+				return err
+			}
+			if len(fields) == 0 {
+				continue
+			}
+
+			if !s.nonInteractive {
+				form := huh.NewForm(huh.NewGroup(fields...).Title(g.Title).Description(g.Description)).
+					WithTheme(&theme).WithAccessible(s.Accessible)
+				if err := form.Run(); err != nil {
+					return err
 				}
 			}
 
-			if len(fields) > 0 {
-				groups = append(groups, huh.NewGroup(fields...).Title(g.Title).Description(g.Description))
+			// Store the answers. Fields skipped above (hidden by
+			// VisibleWhen, or already answered) have no ref.
+			for _, field := range g.ValueFields() {
+				if field.ref != nil {
+					s.answers[field.Key] = field.ref.GetValue()
+				}
 			}
 		}
+	}
 
-		if len(groups) > 0 {
-			// Collect answers for the form
-			form := huh.NewForm(groups...).WithTheme(&theme).WithAccessible(s.Accessible)
-			if err := form.Run(); err != nil {
-				return err
+	return nil
+}
+
+// buildGroupFields evaluates VisibleWhen/SkipWhen/RequiredWhen/OptionsFrom
+// for every field in g against the answers accumulated so far and returns
+// the resulting huh.Fields, skipping any field that isn't visible.
+func (s *Survey) buildGroupFields(g *Group) ([]huh.Field, error) {
+	fields := make([]huh.Field, 0)
+
+	for _, field := range g.Fields {
+		visible, err := s.evalCondition(field.VisibleWhen, field.SkipWhen, true)
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			continue
+		}
+
+		if field.Type != "note" {
+			if _, answered := s.answers[field.Key]; answered {
+				continue
 			}
+		}
+
+		required, err := s.fieldRequired(field, g)
+		if err != nil {
+			return nil, err
+		}
+		field.Required = required
+
+		if required && field.Type != "note" && s.nonInteractive && !hasDefault(field) {
+			return nil, &MissingAnswerError{Key: field.Key}
+		}
 
-			// Store the answers
-			for _, field := range f.ValueFields() {
-				s.answers[field.Key] = field.ref.GetValue()
+		if field.OptionsFrom != "" {
+			options, err := s.optionsFromTemplate(field)
+			if err != nil {
+				return nil, err
 			}
+			field.Options = options
+		}
+
+		switch field.Type {
+		case "note":
+			fields = append(fields, s.NewNoteField(field))
+		case "input":
+			fields = append(fields, s.NewInputField(field))
+		case "text":
+			fields = append(fields, s.NewTextField(field))
+		case "password":
+			fields = append(fields, s.NewPasswordField(field))
+		case "select":
+			fields = append(fields, s.NewSelectField(field))
+		case "multiselect":
+			fields = append(fields, s.NewMultiSelectField(field))
+		case "confirm":
+			fields = append(fields, s.NewConfirmField(field))
+		default:
+			return nil, fmt.Errorf("unsupported field type: %s", field.Type)
 		}
 	}
 
-	return nil
+	return fields, nil
+}
+
+// evalCondition resolves a field/group's effective visibility: visibleWhen
+// (if set) overrides defaultVal, then skipWhen (if set and true) always
+// forces it hidden, regardless of visibleWhen.
+func (s *Survey) evalCondition(visibleWhen, skipWhen string, defaultVal bool) (bool, error) {
+	visible := defaultVal
+	if visibleWhen != "" {
+		ok, err := s.evalExpr(visibleWhen)
+		if err != nil {
+			return false, err
+		}
+		visible = ok
+	}
+	if skipWhen != "" {
+		ok, err := s.evalExpr(skipWhen)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			visible = false
+		}
+	}
+	return visible, nil
+}
+
+// fieldRequired resolves a field's effective Required: f.RequiredWhen takes
+// priority, falling back to g.RequiredWhen (required if any field in the
+// group opts into it), falling back to the static f.Required.
+func (s *Survey) fieldRequired(f *Field, g *Group) (bool, error) {
+	if f.RequiredWhen != "" {
+		return s.evalExpr(f.RequiredWhen)
+	}
+	if g.RequiredWhen != "" {
+		ok, err := s.evalExpr(g.RequiredWhen)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return f.Required, nil
+}
+
+// evalExpr renders expr through ParseTemplate and reports whether it
+// rendered to the literal string "true", e.g. {{ eq .env "prod" }}.
+func (s *Survey) evalExpr(expr string) (bool, error) {
+	out, err := s.ParseTemplate(expr, "condition")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) == "true", nil
+}
+
+// optionsFromTemplate renders f.OptionsFrom against the accumulated answers
+// and unmarshals the result into select options, letting a select/multiselect
+// be populated from an earlier answer (e.g. a previous multiselect).
+func (s *Survey) optionsFromTemplate(f *Field) ([]SelectOption, error) {
+	rendered, err := s.ParseTemplate(f.OptionsFrom, f.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	var options []SelectOption
+	if err := yaml.Unmarshal([]byte(rendered), &options); err != nil {
+		return nil, fmt.Errorf("invalid optionsFrom output for %s: %w", f.Key, err)
+	}
+	return options, nil
 }
 
 func (s Survey) NewNoteField(f *Field) huh.Field {
@@ -177,18 +405,39 @@ func (s Survey) NewInputField(f *Field) huh.Field {
 			value = a
 		}
 	}
-	// TODO: Add support for password
 	field := huh.NewInput().
 		Title(strings.TrimSpace(f.Title)).
 		Description(strings.TrimSpace(f.Description)).
 		Placeholder(f.Placeholder).
 		Value(&value).
-		Validate(func(s string) error {
-			if f.Required && s == "" {
-				return fmt.Errorf("value is required")
-			}
-			return nil
-		})
+		Validate(f.validate)
+	f.ref = field
+	return field
+}
+
+func (s Survey) NewPasswordField(f *Field) huh.Field {
+	value := ""
+	switch f.Default.(type) {
+	case string:
+		value = f.Default.(string)
+		v, err := s.ParseTemplate(value, f.Key)
+		if err != nil {
+			panic(err)
+		}
+		value = v
+	}
+	if s.answers != nil {
+		if a, ok := s.answers[f.Key].(string); ok {
+			value = a
+		}
+	}
+	field := huh.NewInput().
+		Title(strings.TrimSpace(f.Title)).
+		Description(strings.TrimSpace(f.Description)).
+		Placeholder(f.Placeholder).
+		EchoMode(huh.EchoModePassword).
+		Value(&value).
+		Validate(f.validate)
 	f.ref = field
 	return field
 }
@@ -214,12 +463,7 @@ func (s Survey) NewTextField(f *Field) huh.Field {
 		Description(strings.TrimSpace(f.Description)).
 		Placeholder(f.Placeholder).
 		Value(&value).
-		Validate(func(s string) error {
-			if f.Required && s == "" {
-				return fmt.Errorf("value is required")
-			}
-			return nil
-		})
+		Validate(f.validate)
 	f.ref = field
 	return field
 }
@@ -340,12 +584,40 @@ func (s Survey) NewConfirmField(f *Field) huh.Field {
 	return field
 }
 
+// templateFuncs are available to every template rendered through
+// ParseTemplate, in addition to the ones html/template provides by default.
+var templateFuncs = template.FuncMap{
+	"has":      sliceContains,
+	"contains": sliceContains,
+}
+
+// sliceContains reports whether v is present in slice, comparing elements by
+// their string representation. It backs the has/contains template helpers.
+func sliceContains(slice interface{}, v interface{}) bool {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return false
+	}
+	target := fmt.Sprintf("%v", v)
+	for i := 0; i < rv.Len(); i++ {
+		if fmt.Sprintf("%v", rv.Index(i).Interface()) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTemplate renders value as a Go template against the answers collected
+// so far: each answered field's value is available as ".<key>" (e.g. .env
+// for a field keyed "env"), and a "has"/"contains" helper checks membership
+// in a slice-valued answer (e.g. a multiselect), as in
+// {{ if has .regions "eu-west-1" }}.
 func (s *Survey) ParseTemplate(value string, key string) (string, error) {
 	if len(value) < 1 {
 		return value, nil
 	}
 
-	tmpl, err := template.New(key).Parse(value)
+	tmpl, err := template.New(key).Funcs(templateFuncs).Parse(value)
 	if err != nil {
 		return "", err
 	}
@@ -365,32 +637,63 @@ func (s *Survey) Answers() ([]byte, error) {
 	for _, f := range s.Forms {
 		for _, g := range f.Groups {
 			for _, field := range g.Fields {
-				o[field.Key] = field.ref.GetValue()
+				if field.ref != nil {
+					o[field.Key] = field.ref.GetValue()
+				} else if v, ok := s.answers[field.Key]; ok {
+					o[field.Key] = v
+				}
 			}
 		}
 	}
 
+	if s.schema != nil {
+		o = unflattenAnswers(o)
+		if err := validateAgainstSchema(s.schema, s.schema, o); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	name := s.rendererName()
+	factory, ok := renderers[name]
+	if !ok {
+		return []byte{}, fmt.Errorf("%w: %s", ErrUnsupportedFileExtension, name)
+	}
+
+	renderer, err := factory(s)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return renderer.Render(o)
+}
+
+// rendererName picks which registered Renderer produces the survey's output:
+// an explicit Template always selects the template renderer, then an
+// explicit Format wins, and otherwise the renderer is inferred from the
+// Output file's extension (falling back to the survey file's own extension
+// when Output is unset or "-").
+func (s *Survey) rendererName() string {
+	if s.Template != "" {
+		return "template"
+	}
+	if s.Format != "" {
+		return s.Format
+	}
+
 	path := s.Output
 	if s.Output == "" || s.Output == "-" {
 		path = s.path
 	}
 
-	// Marshal the file
-	switch fileType(path) {
-	case "yaml":
-		b, err := yaml.Marshal(o)
-		if err != nil {
-			return []byte{}, err
-		}
-		return b, nil
-	case "json":
-		b, err := json.Marshal(o)
-		if err != nil {
-			return []byte{}, err
-		}
-		return b, nil
+	switch ext := strings.TrimPrefix(filepath.Ext(path), "."); ext {
+	case "yml":
+		return "yaml"
+	case "tf":
+		return "tfvars"
+	case "env":
+		return "dotenv"
 	default:
-		return []byte{}, ErrUnsupportedFileExtension
+		return ext
 	}
 }
 
@@ -414,6 +717,9 @@ func NewSurvey(path string) (Survey, error) {
 			return s, err
 		}
 	case "json":
+		if isJSONSchema(b) {
+			return NewSurveyFromSchema(path)
+		}
 		err = json.Unmarshal(b, &s)
 		if err != nil {
 			return s, err
@@ -422,6 +728,16 @@ func NewSurvey(path string) (Survey, error) {
 		return s, ErrUnsupportedFileExtension
 	}
 
+	if len(s.Includes) > 0 {
+		merged, err := resolveIncludes(&s, filepath.Dir(path), path, map[string]bool{})
+		if err != nil {
+			return s, err
+		}
+		merged.path = s.path
+		merged.answers = s.answers
+		s = *merged
+	}
+
 	if s.Output != "" && s.Output != "-" {
 		// Read the answers
 		a, err := readAnswers(s.Output)
@@ -480,26 +796,6 @@ func readAnswers(path string) (map[string]interface{}, error) {
 	return o, nil
 }
 
-func getTheme(name string) huh.Theme {
-	if name == "" {
-		name = DefaultTheme
-	}
-	switch name {
-	case "base":
-		return *huh.ThemeBase()
-	case "base16":
-		return *huh.ThemeBase16()
-	case "charm":
-		return *huh.ThemeCharm()
-	case "catppuccin":
-		return *huh.ThemeCatppuccin()
-	case "dracula":
-		return *huh.ThemeDracula()
-	default:
-		panic(fmt.Errorf("unsupported theme: %s", name))
-	}
-}
-
 func writeGroupSummary(g *Group, theme *huh.Theme) {
 	w := os.Stdout
 	re := lipgloss.NewRenderer(w)
@@ -509,8 +805,8 @@ func writeGroupSummary(g *Group, theme *huh.Theme) {
 
 	headerStyle := titleStyle.Copy().Bold(true).Align(lipgloss.Center)
 	cellStyle := baseStyle.Copy().Padding(0, 1).Width(14)
-	oddRowStyle := cellStyle.Copy().Foreground(lipgloss.Color("245"))
-	evenRowStyle := cellStyle.Copy().Foreground(lipgloss.Color("242"))
+	oddRowStyle := cellStyle.Copy().Foreground(theme.Blurred.Title.GetForeground())
+	evenRowStyle := cellStyle.Copy().Foreground(theme.Blurred.Description.GetForeground())
 	borderStyle := descriptionStyle.Copy()
 	qColWidth := 20
 	aColWidht := 20
@@ -526,6 +822,9 @@ func writeGroupSummary(g *Group, theme *huh.Theme) {
 	}
 
 	for _, field := range g.ValueFields() {
+		if field.ref == nil {
+			continue
+		}
 		answer := fmt.Sprintf("%v", field.ref.GetValue())
 		rows = append(rows, []string{field.Title, answer, field.Key})
 		if len(field.Title) > qColWidth {
@@ -570,22 +869,95 @@ func writeGroupSummary(g *Group, theme *huh.Theme) {
 	fmt.Fprintln(w)
 }
 
+// repeatedFlag collects every occurrence of a flag.Var flag that may be
+// passed more than once, such as --set.
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string { return strings.Join(*r, ",") }
+
+func (r *repeatedFlag) Set(v string) error {
+	*r = append(*r, v)
+	return nil
+}
+
+// cliError is the machine-readable shape written to stderr by fail() when
+// running non-interactively, so CI pipelines can parse the failure reason.
+type cliError struct {
+	Error string `json:"error"`
+	Key   string `json:"key,omitempty"`
+}
+
+// fail reports err and exits non-zero. In non-interactive mode it writes a
+// JSON error to stderr instead of a plain log line, so scripts driving dhuh
+// can parse the failure reason.
+func fail(nonInteractive bool, err error) {
+	if !nonInteractive {
+		log.Fatal(err)
+	}
+
+	ce := cliError{Error: err.Error()}
+	if missing, ok := err.(*MissingAnswerError); ok {
+		ce.Key = missing.Key
+	}
+
+	b, _ := json.Marshal(ce)
+	fmt.Fprintln(os.Stderr, string(b))
+	os.Exit(1)
+}
+
 func main() {
+	schema := flag.Bool("schema", false, "parse the survey file as a JSON Schema document instead of the bespoke survey format")
+	nonInteractive := flag.Bool("non-interactive", false, "fail instead of prompting when a required field has no supplied value")
+	answerFile := flag.String("answer-file", "", "preload answers from an external YAML/JSON file")
+	printTheme := flag.Bool("print-theme", false, "print the effective theme as YAML and exit, for forking into a theme file")
+	var sets repeatedFlag
+	var setFiles repeatedFlag
+	flag.Var(&sets, "set", "set a field's answer as key=value (repeatable)")
+	flag.Var(&setFiles, "set-file", "set a field's answer from a file as key=@path (repeatable)")
+	flag.Parse()
+
 	path := "survey.yaml"
-	if len(os.Args) > 1 {
-		path = os.Args[1]
+	if flag.NArg() > 0 {
+		path = flag.Arg(0)
 	}
 
-	s, err := NewSurvey(path)
+	var s Survey
+	var err error
+	if *schema {
+		s, err = NewSurveyFromSchema(path)
+	} else {
+		s, err = NewSurvey(path)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if *printTheme {
+		surveyTheme, err := getTheme(&s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b, err := dumpTheme(surveyTheme)
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Stdout.Write(b)
+		return
+	}
+
+	s.nonInteractive = *nonInteractive
+	if err := s.PreloadAnswers(*answerFile, sets, setFiles); err != nil {
+		fail(*nonInteractive, err)
+	}
+
 	if err := s.Run(); err != nil {
-		log.Fatal(err)
+		fail(*nonInteractive, err)
 	}
 
-	surveyTheme := getTheme(s.Theme)
+	surveyTheme, err := getTheme(&s)
+	if err != nil {
+		fail(*nonInteractive, err)
+	}
 	if s.Summary {
 		for _, f := range s.Forms {
 			for _, g := range f.Groups {
@@ -595,7 +967,7 @@ func main() {
 	}
 
 	ok := true
-	if len(s.Confirm.Title) > 0 {
+	if len(s.Confirm.Title) > 0 && !*nonInteractive {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewConfirm().
@@ -616,7 +988,7 @@ func main() {
 
 	a, err := s.Answers()
 	if err != nil {
-		log.Fatal(err)
+		fail(*nonInteractive, err)
 	}
 
 	if s.Output == "" || s.Output == "-" {
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Renderer turns a survey's collected answers into the bytes written to
+// Output (or stdout). Built-ins are registered by name in renderers; third
+// parties importing this package can add their own with RegisterRenderer.
+type Renderer interface {
+	Render(o map[string]interface{}) ([]byte, error)
+}
+
+// RendererFunc adapts a plain function to the Renderer interface.
+type RendererFunc func(o map[string]interface{}) ([]byte, error)
+
+func (f RendererFunc) Render(o map[string]interface{}) ([]byte, error) {
+	return f(o)
+}
+
+// RendererFactory builds a Renderer for a given survey, so renderers that
+// need survey-level configuration (the template renderer reads s.Template)
+// can access it.
+type RendererFactory func(s *Survey) (Renderer, error)
+
+var renderers = map[string]RendererFactory{}
+
+// RegisterRenderer makes a renderer available by name, for selection via
+// Output's file extension or the survey's format/template fields.
+func RegisterRenderer(name string, factory RendererFactory) {
+	renderers[name] = factory
+}
+
+func init() {
+	RegisterRenderer("yaml", func(s *Survey) (Renderer, error) {
+		return RendererFunc(renderYAML), nil
+	})
+	RegisterRenderer("json", func(s *Survey) (Renderer, error) {
+		return RendererFunc(renderJSON), nil
+	})
+	RegisterRenderer("toml", func(s *Survey) (Renderer, error) {
+		return RendererFunc(renderTOML), nil
+	})
+	RegisterRenderer("dotenv", func(s *Survey) (Renderer, error) {
+		return RendererFunc(renderDotenv), nil
+	})
+	RegisterRenderer("hcl", func(s *Survey) (Renderer, error) {
+		return RendererFunc(renderTfvars), nil
+	})
+	RegisterRenderer("tfvars", func(s *Survey) (Renderer, error) {
+		return RendererFunc(renderTfvars), nil
+	})
+	RegisterRenderer("template", newTemplateRenderer)
+}
+
+func renderYAML(o map[string]interface{}) ([]byte, error) {
+	return yaml.Marshal(o)
+}
+
+func renderJSON(o map[string]interface{}) ([]byte, error) {
+	return json.Marshal(o)
+}
+
+func renderTOML(o map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(o); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderDotenv writes one KEY=value line per answer, sorted by key so the
+// output is stable across runs. Keys are upper-cased with anything other
+// than a letter/digit turned into an underscore so they're valid shell/env
+// variable names even when the survey key uses dots (from nested fields) or
+// dashes.
+func renderDotenv(o map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, key := range sortedKeys(o) {
+		fmt.Fprintf(&buf, "%s=%s\n", dotenvKey(key), dotenvValue(o[key]))
+	}
+	return buf.Bytes(), nil
+}
+
+func dotenvKey(key string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+func dotenvValue(v interface{}) string {
+	s := fmt.Sprintf("%v", v)
+	if s == "" || strings.ContainsAny(s, " \t\"'#") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// renderTfvars writes the answers as Terraform-style key = value
+// assignments (the "hcl"/"tfvars" renderer), one per line sorted by key.
+func renderTfvars(o map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, key := range sortedKeys(o) {
+		fmt.Fprintf(&buf, "%s = %s\n", key, hclLiteral(o[key]))
+	}
+	return buf.Bytes(), nil
+}
+
+func hclLiteral(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = hclLiteral(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	default:
+		return strconv.Quote(fmt.Sprintf("%v", v))
+	}
+}
+
+func sortedKeys(o map[string]interface{}) []string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// templateRenderFuncs are available to a survey's Template file, mirroring
+// the handful of sprig helpers most config-file templates reach for.
+var templateRenderFuncs = texttemplate.FuncMap{
+	"quote": func(v interface{}) string { return strconv.Quote(fmt.Sprintf("%v", v)) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"default": func(def, v interface{}) interface{} {
+		if v == nil || fmt.Sprintf("%v", v) == "" {
+			return def
+		}
+		return v
+	},
+	"join": func(sep string, v interface{}) string {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return fmt.Sprintf("%v", v)
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = fmt.Sprintf("%v", rv.Index(i).Interface())
+		}
+		return strings.Join(items, sep)
+	},
+	"indent": func(spaces int, v string) string {
+		pad := strings.Repeat(" ", spaces)
+		lines := strings.Split(v, "\n")
+		for i, line := range lines {
+			lines[i] = pad + line
+		}
+		return strings.Join(lines, "\n")
+	},
+}
+
+// newTemplateRenderer reads s.Template and returns a Renderer that executes
+// it as a Go text/template against the answers map, so a survey can produce
+// Kubernetes manifests, Terraform .tfvars, or other config fragments
+// directly instead of piping its YAML/JSON output through a second tool.
+func newTemplateRenderer(s *Survey) (Renderer, error) {
+	if s.Template == "" {
+		return nil, fmt.Errorf("template renderer selected but no template file is configured")
+	}
+
+	b, err := os.ReadFile(s.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := texttemplate.New(filepath.Base(s.Template)).Funcs(templateRenderFuncs).Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return RendererFunc(func(o map[string]interface{}) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, o); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}), nil
+}
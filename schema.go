@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// JSONSchema is a minimal subset of JSON Schema (draft 2020-12) sufficient to
+// synthesize a Survey: object/string/boolean/array types, enums, $ref within
+// the same document, and the validation keywords mirrored on Field.
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Ref         string                 `json:"$ref,omitempty"`
+	Defs        map[string]*JSONSchema `json:"$defs,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Default     interface{}            `json:"default,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	Pattern     string                 `json:"pattern,omitempty"`
+	MinLength   *int                   `json:"minLength,omitempty"`
+	MaxLength   *int                   `json:"maxLength,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Items       *JSONSchema            `json:"items,omitempty"`
+}
+
+// isJSONSchema reports whether b looks like a JSON Schema document rather
+// than a bespoke dhuh survey: the bespoke format has no top-level "$schema"
+// or "properties" keywords.
+func isJSONSchema(b []byte) bool {
+	var probe struct {
+		Schema     string                 `json:"$schema"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return false
+	}
+	return strings.Contains(probe.Schema, "json-schema.org") || probe.Properties != nil
+}
+
+// NewSurveyFromSchema reads a JSON Schema document at path and synthesizes a
+// Survey from it: a single Form/Group mirrors the schema's top-level
+// properties, with nested objects flattened into dotted-key fields on the
+// same Group since huh has no notion of nested groups.
+func NewSurveyFromSchema(path string) (Survey, error) {
+	s := Survey{
+		path:    path,
+		answers: map[string]interface{}{},
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+
+	root := &JSONSchema{}
+	if err := json.Unmarshal(b, root); err != nil {
+		return s, err
+	}
+
+	s.Name = root.Title
+	s.Description = root.Description
+
+	groups, err := groupFromSchema(root.Title, root.Description, root, root, "")
+	if err != nil {
+		return s, err
+	}
+
+	s.Forms = []*Form{{Groups: groups}}
+	s.schema = root
+
+	if s.Output != "" && s.Output != "-" {
+		a, err := readAnswers(s.Output)
+		if err != nil {
+			return s, err
+		}
+		s.answers = a
+	}
+
+	return s, nil
+}
+
+// validateAgainstSchema checks that every property the schema marks as
+// required is present and non-empty in o, recursing into nested objects
+// against their own nested map so the check mirrors the schema's actual
+// structure rather than the dotted keys fieldFromSchema uses internally.
+func validateAgainstSchema(sch *JSONSchema, root *JSONSchema, o map[string]interface{}) error {
+	for _, key := range sch.Required {
+		prop, err := resolveRef(sch.Properties[key], root)
+		if err != nil {
+			return err
+		}
+		if prop.Type != "object" {
+			if v, ok := o[key]; !ok || v == "" || v == nil {
+				return fmt.Errorf("missing required value: %s", key)
+			}
+		}
+	}
+
+	for key, prop := range sch.Properties {
+		resolved, err := resolveRef(prop, root)
+		if err != nil {
+			return err
+		}
+		if resolved.Type != "object" {
+			continue
+		}
+		nested, _ := o[key].(map[string]interface{})
+		if nested == nil {
+			nested = map[string]interface{}{}
+		}
+		if err := validateAgainstSchema(resolved, root, nested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unflattenAnswers expands the dotted keys fieldFromSchema produces for
+// nested object properties (e.g. "network.cidr") back into nested maps (e.g.
+// {"network": {"cidr": ...}}), so Answers() output matches the schema's own
+// structure instead of a flat key a schema validator wouldn't recognize.
+func unflattenAnswers(o map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, v := range o {
+		parts := strings.Split(key, ".")
+		m := result
+		for i, part := range parts {
+			if i == len(parts)-1 {
+				m[part] = v
+				break
+			}
+			next, ok := m[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				m[part] = next
+			}
+			m = next
+		}
+	}
+	return result
+}
+
+// groupFromSchema walks an object schema's properties into a Group, resolving
+// $ref against root and recursing into nested objects with a dotted key
+// prefix (e.g. a "network" object with a "cidr" property becomes a field
+// keyed "network.cidr"). Nested objects become their own Groups, returned
+// after the one built from sch's own scalar properties.
+func groupFromSchema(title, description string, sch *JSONSchema, root *JSONSchema, prefix string) ([]*Group, error) {
+	g := &Group{Title: title, Description: description}
+	groups := []*Group{g}
+
+	keys := make([]string, 0, len(sch.Properties))
+	for k := range sch.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	required := map[string]bool{}
+	for _, k := range sch.Required {
+		required[k] = true
+	}
+
+	for _, key := range keys {
+		prop, err := resolveRef(sch.Properties[key], root)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldKey := key
+		if prefix != "" {
+			fieldKey = prefix + "." + key
+		}
+
+		if prop.Type == "object" {
+			nested, err := groupFromSchema(titleOr(prop.Title, key), prop.Description, prop, root, fieldKey)
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, nested...)
+			continue
+		}
+
+		field, err := fieldFromSchema(fieldKey, prop, required[key])
+		if err != nil {
+			return nil, err
+		}
+		g.Fields = append(g.Fields, field)
+	}
+
+	return groups, nil
+}
+
+// resolveRef follows a single "#/$defs/Name" reference against root.
+func resolveRef(sch *JSONSchema, root *JSONSchema) (*JSONSchema, error) {
+	if sch.Ref == "" {
+		return sch, nil
+	}
+	name := strings.TrimPrefix(sch.Ref, "#/$defs/")
+	if name == sch.Ref {
+		return nil, fmt.Errorf("unsupported $ref: %s", sch.Ref)
+	}
+	def, ok := root.Defs[name]
+	if !ok {
+		return nil, fmt.Errorf("$ref not found: %s", sch.Ref)
+	}
+	return def, nil
+}
+
+// fieldFromSchema maps a single leaf schema to a Field: enums become
+// select/multiselect, booleans become confirm, format: password becomes a
+// masked input, and long or multiline strings become a text field instead of
+// a single-line input.
+func fieldFromSchema(key string, sch *JSONSchema, required bool) (*Field, error) {
+	f := &Field{
+		Key:         key,
+		Title:       titleOr(sch.Title, key),
+		Description: sch.Description,
+		Required:    required,
+		Default:     sch.Default,
+		Pattern:     sch.Pattern,
+		MinLength:   sch.MinLength,
+		MaxLength:   sch.MaxLength,
+		Minimum:     sch.Minimum,
+		Maximum:     sch.Maximum,
+	}
+
+	switch {
+	case sch.Type == "array" && sch.Items != nil && len(sch.Items.Enum) > 0:
+		f.Type = "multiselect"
+		f.Options = optionsFromEnum(sch.Items.Enum)
+	case len(sch.Enum) > 0:
+		f.Type = "select"
+		f.Options = optionsFromEnum(sch.Enum)
+	case sch.Type == "boolean":
+		f.Type = "confirm"
+	case sch.Format == "password":
+		f.Type = "password"
+	case sch.Format == "multiline" || (sch.MaxLength != nil && *sch.MaxLength > 255):
+		f.Type = "text"
+	default:
+		f.Type = "input"
+	}
+
+	return f, nil
+}
+
+func optionsFromEnum(values []interface{}) []SelectOption {
+	options := make([]SelectOption, 0, len(values))
+	for _, v := range values {
+		value := fmt.Sprintf("%v", v)
+		options = append(options, SelectOption{Key: value, Value: value})
+	}
+	return options
+}
+
+func titleOr(title, fallback string) string {
+	if title != "" {
+		return title
+	}
+	return fallback
+}
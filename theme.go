@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// ThemeFactory builds one of the built-in themes. Third-party code can add
+// its own with RegisterTheme.
+type ThemeFactory func() *huh.Theme
+
+var themeRegistry = map[string]ThemeFactory{
+	"base":       huh.ThemeBase,
+	"base16":     huh.ThemeBase16,
+	"charm":      huh.ThemeCharm,
+	"catppuccin": huh.ThemeCatppuccin,
+	"dracula":    huh.ThemeDracula,
+}
+
+// RegisterTheme makes a built-in theme available by name, for selection via
+// the survey's theme field.
+func RegisterTheme(name string, factory ThemeFactory) {
+	themeRegistry[name] = factory
+}
+
+// StyleSpec describes a single theme slot override in terms of lipgloss
+// primitives: hex ("#ff0000") or ANSI-256 ("245") colours, bold/italic/
+// underline flags, and padding/margin.
+type StyleSpec struct {
+	Foreground       string `yaml:"foreground,omitempty" json:"foreground,omitempty"`
+	Background       string `yaml:"background,omitempty" json:"background,omitempty"`
+	BorderForeground string `yaml:"borderForeground,omitempty" json:"borderForeground,omitempty"`
+	Bold             *bool  `yaml:"bold,omitempty" json:"bold,omitempty"`
+	Italic           *bool  `yaml:"italic,omitempty" json:"italic,omitempty"`
+	Underline        *bool  `yaml:"underline,omitempty" json:"underline,omitempty"`
+	PaddingTop       int    `yaml:"paddingTop,omitempty" json:"paddingTop,omitempty"`
+	PaddingRight     int    `yaml:"paddingRight,omitempty" json:"paddingRight,omitempty"`
+	PaddingBottom    int    `yaml:"paddingBottom,omitempty" json:"paddingBottom,omitempty"`
+	PaddingLeft      int    `yaml:"paddingLeft,omitempty" json:"paddingLeft,omitempty"`
+	MarginTop        int    `yaml:"marginTop,omitempty" json:"marginTop,omitempty"`
+	MarginRight      int    `yaml:"marginRight,omitempty" json:"marginRight,omitempty"`
+	MarginBottom     int    `yaml:"marginBottom,omitempty" json:"marginBottom,omitempty"`
+	MarginLeft       int    `yaml:"marginLeft,omitempty" json:"marginLeft,omitempty"`
+}
+
+// apply layers spec's set fields on top of style, leaving anything spec
+// doesn't mention untouched.
+func (spec StyleSpec) apply(style lipgloss.Style) lipgloss.Style {
+	if spec.Foreground != "" {
+		style = style.Foreground(lipgloss.Color(spec.Foreground))
+	}
+	if spec.Background != "" {
+		style = style.Background(lipgloss.Color(spec.Background))
+	}
+	if spec.BorderForeground != "" {
+		style = style.BorderForeground(lipgloss.Color(spec.BorderForeground))
+	}
+	if spec.Bold != nil {
+		style = style.Bold(*spec.Bold)
+	}
+	if spec.Italic != nil {
+		style = style.Italic(*spec.Italic)
+	}
+	if spec.Underline != nil {
+		style = style.Underline(*spec.Underline)
+	}
+	if spec.PaddingTop != 0 || spec.PaddingRight != 0 || spec.PaddingBottom != 0 || spec.PaddingLeft != 0 {
+		style = style.Padding(spec.PaddingTop, spec.PaddingRight, spec.PaddingBottom, spec.PaddingLeft)
+	}
+	if spec.MarginTop != 0 || spec.MarginRight != 0 || spec.MarginBottom != 0 || spec.MarginLeft != 0 {
+		style = style.Margin(spec.MarginTop, spec.MarginRight, spec.MarginBottom, spec.MarginLeft)
+	}
+	return style
+}
+
+// themeSlot addresses one named style on a huh.Theme, letting StyleSpec
+// overrides be applied by slot name (e.g. "focused.title", "help").
+type themeSlot struct {
+	get func(t *huh.Theme) lipgloss.Style
+	set func(t *huh.Theme, s lipgloss.Style)
+}
+
+// themeSlots covers the semantic slots a survey author is likely to want to
+// override; it isn't every lipgloss.Style on huh.Theme.
+var themeSlots = map[string]themeSlot{
+	"form.base": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Form.Base },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Form.Base = s },
+	},
+	"group.title": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Group.Title },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Group.Title = s },
+	},
+	"group.description": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Group.Description },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Group.Description = s },
+	},
+	"focused.title": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Focused.Title },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Focused.Title = s },
+	},
+	"focused.description": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Focused.Description },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Focused.Description = s },
+	},
+	"blurred.title": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Blurred.Title },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Blurred.Title = s },
+	},
+	"blurred.description": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Blurred.Description },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Blurred.Description = s },
+	},
+	"error": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Focused.ErrorMessage },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Focused.ErrorMessage = s; t.Focused.ErrorIndicator = s },
+	},
+	"help": {
+		get: func(t *huh.Theme) lipgloss.Style { return t.Help.ShortDesc },
+		set: func(t *huh.Theme, s lipgloss.Style) { t.Help.ShortDesc = s; t.Help.FullDesc = s },
+	},
+}
+
+// getTheme resolves the theme a survey should run with: an inline
+// ThemeStyles block or a `theme: path/to/theme.yaml` file selects the
+// override path (starting from huh.ThemeBase() and layering StyleSpecs
+// slot-by-slot), otherwise Theme names a built-in from themeRegistry.
+func getTheme(s *Survey) (huh.Theme, error) {
+	if len(s.ThemeStyles) > 0 {
+		t := huh.ThemeBase()
+		if err := applyThemeStyles(t, s.ThemeStyles); err != nil {
+			return huh.Theme{}, err
+		}
+		return *t, nil
+	}
+
+	if isThemeFile(s.Theme) {
+		specs, err := loadThemeFile(s.Theme)
+		if err != nil {
+			return huh.Theme{}, err
+		}
+		t := huh.ThemeBase()
+		if err := applyThemeStyles(t, specs); err != nil {
+			return huh.Theme{}, err
+		}
+		return *t, nil
+	}
+
+	name := s.Theme
+	if name == "" {
+		name = DefaultTheme
+	}
+
+	factory, ok := themeRegistry[name]
+	if !ok {
+		return huh.Theme{}, fmt.Errorf("unsupported theme: %s", name)
+	}
+
+	return *factory(), nil
+}
+
+func isThemeFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func loadThemeFile(path string) (map[string]StyleSpec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs map[string]StyleSpec
+	if err := yaml.Unmarshal(b, &specs); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+func applyThemeStyles(t *huh.Theme, specs map[string]StyleSpec) error {
+	for slot, spec := range specs {
+		mapping, ok := themeSlots[slot]
+		if !ok {
+			return fmt.Errorf("unknown theme slot: %s", slot)
+		}
+		mapping.set(t, spec.apply(mapping.get(t)))
+	}
+	return nil
+}
+
+// dumpTheme renders the effective style of every known slot as YAML, for
+// --print-theme: a user can redirect this to a file, tweak it, and point
+// `theme:` at the result to fork a built-in.
+func dumpTheme(t huh.Theme) ([]byte, error) {
+	specs := make(map[string]StyleSpec, len(themeSlots))
+	for slot, mapping := range themeSlots {
+		style := mapping.get(&t)
+		spec := StyleSpec{}
+		spec.Foreground = colorString(style.GetForeground())
+		spec.Background = colorString(style.GetBackground())
+		bold := style.GetBold()
+		italic := style.GetItalic()
+		underline := style.GetUnderline()
+		spec.Bold = &bold
+		spec.Italic = &italic
+		spec.Underline = &underline
+		spec.PaddingTop, spec.PaddingRight, spec.PaddingBottom, spec.PaddingLeft = style.GetPadding()
+		spec.MarginTop, spec.MarginRight, spec.MarginBottom, spec.MarginLeft = style.GetMargin()
+		specs[slot] = spec
+	}
+
+	return yaml.Marshal(specs)
+}
+
+// colorString reduces a lipgloss.TerminalColor back to the single hex/ANSI-256
+// string StyleSpec understands, taking the dark variant of an AdaptiveColor
+// and returning "" for NoColor.
+func colorString(c lipgloss.TerminalColor) string {
+	switch c := c.(type) {
+	case lipgloss.Color:
+		return string(c)
+	case lipgloss.ANSIColor:
+		return fmt.Sprintf("%d", uint(c))
+	case lipgloss.AdaptiveColor:
+		return c.Dark
+	case lipgloss.CompleteColor:
+		return c.TrueColor
+	case lipgloss.CompleteAdaptiveColor:
+		return c.Dark.TrueColor
+	default:
+		return ""
+	}
+}